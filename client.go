@@ -0,0 +1,310 @@
+package openpoker
+
+import (
+	"net"
+	"sync"
+)
+
+// SeatState is the last known state of a single seat at a table, built up
+// from MsgSeatInfo notifications.
+type SeatState struct {
+	SeatNum Small
+	State   PlayerState
+	Pid     Id
+	InPlay  Amount
+}
+
+// GameState is the Client's tracked view of a single table (Gid), kept up
+// to date as NotifyXxx messages arrive.
+type GameState struct {
+	Gid       Id
+	Seats     map[Small]*SeatState
+	Community Cards
+	Stage     GameStage
+	Button    Small
+	Sb        Small
+	Bb        Small
+	lastBet   *MsgBetReq
+}
+
+// Event is a decoded message paired with its Cmd, delivered on the channel
+// returned by Client.Events for callers who prefer select-style consumption
+// over registering handlers.
+type Event struct {
+	Cmd Cmd
+	Msg interface{}
+}
+
+// Client wraps a net.Conn and maintains the table/seat/pot bookkeeping that
+// every caller of the raw WriteXxx/ReadMsg functions would otherwise have to
+// reimplement. Handlers are plain func fields, set before calling Run; any
+// left nil are simply not invoked. Games, HoleCards and Pid/Balance are safe
+// to read from a handler but should not be mutated by callers.
+type Client struct {
+	conn net.Conn
+
+	mu        sync.Mutex
+	Pid       Id
+	Balance   Amount
+	Games     map[Id]*GameState
+	HoleCards map[Id]Cards
+
+	OnGood          func(*MsgGood)
+	OnBad           func(*Error)
+	OnYouAre        func(*MsgYouAre)
+	OnYourGame      func(*MsgYourGame)
+	OnSeatInfo      func(*MsgSeatInfo)
+	OnGameInfo      func(*MsgGameInfo)
+	OnBetReq        func(*MsgBetReq)
+	OnBalance       func(*MsgBalance)
+	OnNotifyJoin    func(*MsgNotifyJoin)
+	OnNotifyLeave   func(*MsgNotifyLeave)
+	OnNotifyRaise   func(*MsgNotifyRaise)
+	OnNotifyDraw    func(*MsgNotifyDraw)
+	OnNotifyShared  func(*MsgNotifyShared)
+	OnNotifyHand    func(*MsgNotifyHand)
+	OnNotifyWin     func(*MsgNotifyWin)
+	OnNotifyButton  func(*MsgNotifyButton)
+	OnNotifySb      func(*MsgNotifySb)
+	OnNotifyBb      func(*MsgNotifyBb)
+	OnGameStage     func(*MsgGameStage)
+	OnNotifyEndGame func(*MsgNotifyEndGame)
+	OnNotifyChat    func(*MsgNotifyChat)
+
+	events chan Event
+}
+
+// NewClient creates a Client around an already-dialled connection. Call Run
+// to start the read loop; it blocks until the connection is closed or a
+// decode error occurs.
+func NewClient(conn net.Conn) *Client {
+	return &Client{
+		conn:      conn,
+		Games:     make(map[Id]*GameState),
+		HoleCards: make(map[Id]Cards),
+	}
+}
+
+// Events returns a channel of decoded messages. The channel is created and
+// buffered on first call; it must be called before Run if the caller wants
+// to observe messages received during the first Run iterations.
+func (c *Client) Events() <-chan Event {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.events == nil {
+		c.events = make(chan Event, 64)
+	}
+	return c.events
+}
+
+// Run reads and dispatches messages until the connection fails or returns
+// an undecodable frame. It updates the Client's tracked state before
+// invoking any registered handler or publishing to Events. Before
+// returning, it closes the channel returned by Events (if any caller ever
+// asked for one) so a `range` over it ends instead of blocking forever.
+func (c *Client) Run() error {
+	for {
+		err, cmd, msg := ReadMsg(c.conn)
+		if err != nil {
+			if c.events != nil {
+				close(c.events)
+			}
+			return err
+		}
+
+		c.track(cmd, msg)
+		c.dispatch(cmd, msg)
+
+		if c.events != nil {
+			c.events <- Event{Cmd: cmd, Msg: msg}
+		}
+	}
+}
+
+func (c *Client) game(gid Id) *GameState {
+	g, ok := c.Games[gid]
+	if !ok {
+		g = &GameState{Gid: gid, Seats: make(map[Small]*SeatState)}
+		c.Games[gid] = g
+	}
+	return g
+}
+
+func (c *Client) track(cmd Cmd, msg interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch m := msg.(type) {
+	case MsgYouAre:
+		c.Pid = m.Pid
+	case MsgBalance:
+		c.Balance = m.Balance
+	case MsgSeatInfo:
+		g := c.game(m.Gid)
+		g.Seats[m.SeatNum] = &SeatState{SeatNum: m.SeatNum, State: m.State, Pid: m.Pid, InPlay: m.InPlay}
+	case MsgNotifyButton:
+		c.game(m.Gid).Button = m.Button
+	case MsgNotifySb:
+		c.game(m.Gid).Sb = m.Sb
+	case MsgNotifyBb:
+		c.game(m.Gid).Bb = m.Bb
+	case MsgGameStage:
+		g := c.game(m.Gid)
+		g.Stage = m.Stage
+		if m.Stage == GS_PREFLOP {
+			g.Community = nil
+			c.HoleCards[m.Gid] = nil
+		}
+	case MsgBetReq:
+		c.game(m.Gid).lastBet = &m
+	case MsgNotifyShared:
+		g := c.game(m.Gid)
+		g.Community = append(g.Community, Card{Seq: m.Seq, Suit: m.Suit})
+	case MsgNotifyDraw:
+		if m.Pid == c.Pid {
+			c.HoleCards[m.Gid] = append(c.HoleCards[m.Gid], Card{Seq: m.Seq, Suit: m.Suit})
+		}
+	case MsgNotifyEndGame:
+		delete(c.Games, m.Gid)
+		delete(c.HoleCards, m.Gid)
+	}
+}
+
+func (c *Client) dispatch(cmd Cmd, msg interface{}) {
+	switch m := msg.(type) {
+	case MsgGood:
+		if c.OnGood != nil {
+			c.OnGood(&m)
+		}
+	case MsgBad:
+		if c.OnBad != nil {
+			c.OnBad(&Error{Cmd: m.Cmd, Code: m.Error})
+		}
+	case MsgYouAre:
+		if c.OnYouAre != nil {
+			c.OnYouAre(&m)
+		}
+	case MsgYourGame:
+		if c.OnYourGame != nil {
+			c.OnYourGame(&m)
+		}
+	case MsgSeatInfo:
+		if c.OnSeatInfo != nil {
+			c.OnSeatInfo(&m)
+		}
+	case MsgGameInfo:
+		if c.OnGameInfo != nil {
+			c.OnGameInfo(&m)
+		}
+	case MsgBetReq:
+		if c.OnBetReq != nil {
+			c.OnBetReq(&m)
+		}
+	case MsgBalance:
+		if c.OnBalance != nil {
+			c.OnBalance(&m)
+		}
+	case MsgNotifyJoin:
+		if c.OnNotifyJoin != nil {
+			c.OnNotifyJoin(&m)
+		}
+	case MsgNotifyLeave:
+		if c.OnNotifyLeave != nil {
+			c.OnNotifyLeave(&m)
+		}
+	case MsgNotifyRaise:
+		if c.OnNotifyRaise != nil {
+			c.OnNotifyRaise(&m)
+		}
+	case MsgNotifyDraw:
+		if c.OnNotifyDraw != nil {
+			c.OnNotifyDraw(&m)
+		}
+	case MsgNotifyShared:
+		if c.OnNotifyShared != nil {
+			c.OnNotifyShared(&m)
+		}
+	case MsgNotifyHand:
+		if c.OnNotifyHand != nil {
+			c.OnNotifyHand(&m)
+		}
+	case MsgNotifyWin:
+		if c.OnNotifyWin != nil {
+			c.OnNotifyWin(&m)
+		}
+	case MsgNotifyButton:
+		if c.OnNotifyButton != nil {
+			c.OnNotifyButton(&m)
+		}
+	case MsgNotifySb:
+		if c.OnNotifySb != nil {
+			c.OnNotifySb(&m)
+		}
+	case MsgNotifyBb:
+		if c.OnNotifyBb != nil {
+			c.OnNotifyBb(&m)
+		}
+	case MsgGameStage:
+		if c.OnGameStage != nil {
+			c.OnGameStage(&m)
+		}
+	case MsgNotifyEndGame:
+		if c.OnNotifyEndGame != nil {
+			c.OnNotifyEndGame(&m)
+		}
+	case MsgNotifyChat:
+		if c.OnNotifyChat != nil {
+			c.OnNotifyChat(&m)
+		}
+	}
+}
+
+// JoinAndSitIn takes a seat at gid and immediately signals readiness to
+// play, combining the two messages a bot normally has to send back to back.
+// It returns the first write's error without attempting the second, since a
+// failed Join means there's no seat to sit in.
+func (c *Client) JoinAndSitIn(gid Id, seat Small, amt Amount) error {
+	if err := WriteJoin(c.conn, gid, seat, amt); err != nil {
+		return err
+	}
+	return WriteComeBack(c.conn, gid)
+}
+
+// CallOrCheck matches the call amount of the last BetReq seen for gid. If
+// no BetReq has been seen yet it does nothing and returns nil.
+func (c *Client) CallOrCheck(gid Id) error {
+	c.mu.Lock()
+	g, ok := c.Games[gid]
+	hasBet := ok && g.lastBet != nil
+	var amt Amount
+	if hasBet {
+		amt = g.lastBet.CallAmount
+	}
+	c.mu.Unlock()
+	if !hasBet {
+		return nil
+	}
+	return WriteRaise(c.conn, gid, amt)
+}
+
+// MinRaise raises by the minimum amount allowed by the last BetReq seen for
+// gid. If no BetReq has been seen yet it does nothing and returns nil.
+func (c *Client) MinRaise(gid Id) error {
+	c.mu.Lock()
+	g, ok := c.Games[gid]
+	hasBet := ok && g.lastBet != nil
+	var amt Amount
+	if hasBet {
+		amt = g.lastBet.RaiseMin
+	}
+	c.mu.Unlock()
+	if !hasBet {
+		return nil
+	}
+	return WriteRaise(c.conn, gid, amt)
+}
+
+// Fold folds the Client's hand at gid.
+func (c *Client) Fold(gid Id) error {
+	return WriteFold(c.conn, gid)
+}
@@ -0,0 +1,129 @@
+package openpoker
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+// TestClientRunDispatch exercises Run end-to-end over a real net.Conn pair,
+// checking that track() actually matches the decoded value types (ReadMsg
+// hands back values, never pointers) and that handlers observe the same
+// state CallOrCheck reads.
+func TestClientRunDispatch(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+
+	c := NewClient(client)
+
+	gotYouAre := make(chan Id, 1)
+	c.OnYouAre = func(m *MsgYouAre) { gotYouAre <- m.Pid }
+	gotBetReq := make(chan Amount, 1)
+	c.OnBetReq = func(m *MsgBetReq) { gotBetReq <- m.CallAmount }
+
+	done := make(chan error, 1)
+	go func() { done <- c.Run() }()
+
+	if err := WriteMsg(server, MsgYouAre{Pid: 7}); err != nil {
+		t.Fatalf("WriteMsg(YouAre): %v", err)
+	}
+	select {
+	case pid := <-gotYouAre:
+		if pid != 7 {
+			t.Fatalf("OnYouAre pid = %d, want 7", pid)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for OnYouAre")
+	}
+	if c.Pid != 7 {
+		t.Fatalf("c.Pid = %d, want 7", c.Pid)
+	}
+
+	if err := WriteMsg(server, MsgBetReq{Gid: 1, CallAmount: 20, RaiseMin: 40, RaiseMax: 1000}); err != nil {
+		t.Fatalf("WriteMsg(BetReq): %v", err)
+	}
+	select {
+	case amt := <-gotBetReq:
+		if amt != 20 {
+			t.Fatalf("OnBetReq CallAmount = %v, want 20", amt)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for OnBetReq")
+	}
+
+	// RAISE is only ever client->server, so there's no registered decoder
+	// for it; read the raw frame and unpack the trailing Amount by hand.
+	raised := make(chan uint32, 1)
+	go func() {
+		var size uint16
+		if err := binary.Read(server, binary.BigEndian, &size); err != nil {
+			return
+		}
+		body := make([]byte, size)
+		if _, err := io.ReadFull(server, body); err != nil {
+			return
+		}
+		raised <- binary.BigEndian.Uint32(body[len(body)-4:])
+	}()
+
+	done2 := make(chan struct{})
+	go func() {
+		if err := c.CallOrCheck(1); err != nil {
+			t.Errorf("CallOrCheck: %v", err)
+		}
+		close(done2)
+	}()
+	select {
+	case <-done2:
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for CallOrCheck")
+	}
+	select {
+	case got := <-raised:
+		if got != 20*100 {
+			t.Fatalf("CallOrCheck raised %d cents, want %d", got, 20*100)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for CallOrCheck's frame")
+	}
+
+	server.Close()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for Run to return")
+	}
+}
+
+// TestRunClosesEvents checks that Run closes the Events channel before
+// returning, so a `for ev := range c.Events()` consumer observes the
+// channel close instead of blocking forever once the connection dies.
+func TestRunClosesEvents(t *testing.T) {
+	server, client := net.Pipe()
+	defer client.Close()
+
+	c := NewClient(client)
+	events := c.Events()
+
+	done := make(chan error, 1)
+	go func() { done <- c.Run() }()
+
+	server.Close()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for Run to return")
+	}
+
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Fatal("Events() channel yielded a value instead of closing")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for Events() channel to close")
+	}
+}
@@ -0,0 +1,182 @@
+package openpoker
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"reflect"
+	"sync"
+)
+
+// decodeFunc reads the body of one message given the Cmd byte has already
+// been consumed and size is the number of body bytes remaining in the
+// frame. It is built once per registration by compileDecoder, which
+// captures the field count up front; each call still allocates the value
+// and reaches its fields through reflection.
+type decodeFunc func(r io.Reader, size uint16) (interface{}, error)
+
+// Codec maps Cmd bytes to message types in both directions. The package
+// preloads DefaultCodec with every built-in message (see messages_gen.go);
+// callers that need private, non-standard command bytes - a modded server,
+// a test harness - can register their own prototype structs on a Codec of
+// their own without touching this package.
+type Codec struct {
+	mu       sync.RWMutex
+	decoders map[Cmd]decodeFunc
+	encoders map[reflect.Type]Cmd
+}
+
+// NewCodec returns an empty Codec. Most callers want DefaultCodec, which
+// already knows every message in this package.
+func NewCodec() *Codec {
+	return &Codec{
+		decoders: make(map[Cmd]decodeFunc),
+		encoders: make(map[reflect.Type]Cmd),
+	}
+}
+
+// DefaultCodec is preloaded with the protocol's built-in messages by an
+// init() in messages_gen.go. ReadMsg and WriteMsg use it.
+var DefaultCodec = NewCodec()
+
+// RegisterMessage registers prototype (a value, not a pointer, of the
+// message's struct type) as the body of cmd, both for decoding frames with
+// that Cmd and for encoding values of that type with WriteMsg. It panics if
+// a field of prototype doesn't implement the readable/writable interfaces,
+// since that can only be a programming error in the caller.
+func (c *Codec) RegisterMessage(cmd Cmd, prototype interface{}) {
+	typ := reflect.TypeOf(prototype)
+
+	for f := 0; f < typ.NumField(); f++ {
+		zero := reflect.New(typ.Field(f).Type)
+		if _, ok := zero.Interface().(readable); !ok {
+			panic("openpoker: " + typ.Field(f).Type.String() + " does not implement readable")
+		}
+		if _, ok := reflect.Zero(typ.Field(f).Type).Interface().(writable); !ok {
+			panic("openpoker: " + typ.Field(f).Type.String() + " does not implement writable")
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.decoders[cmd] = compileDecoder(cmd, typ)
+	c.encoders[typ] = cmd
+}
+
+// compileDecoder builds the closure that decodes one instance of typ. The
+// field count and type are captured once here instead of being re-derived
+// from typ on every call. Reads are bounded to size via io.LimitReader so a
+// malformed length prefix can make a message look short (caught below) but
+// can never make it consume bytes belonging to the next frame.
+func compileDecoder(cmd Cmd, typ reflect.Type) decodeFunc {
+	n := typ.NumField()
+	return func(r io.Reader, size uint16) (interface{}, error) {
+		lr := io.LimitReader(r, int64(size))
+		v := reflect.New(typ).Elem()
+
+		var consumed int64
+		var err error
+		for f := 0; f < n; f++ {
+			field := v.Field(f).Addr().Interface().(readable)
+			ReadFrom(lr, field, &consumed, &err)
+		}
+
+		if err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return nil, &ErrShortMessage{cmd, size - uint16(consumed)}
+			}
+			return nil, err
+		}
+
+		if consumed < int64(size) {
+			return nil, &ErrLongMessage{cmd, size - uint16(consumed)}
+		}
+
+		return v.Interface(), nil
+	}
+}
+
+// Decode reads the body of a message whose Cmd and declared body size are
+// already known, e.g. because a caller peeled the frame header off the
+// wire itself (openpoker/proxy does this to forward unmodified frames
+// byte-for-byte instead of re-encoding them).
+func (c *Codec) Decode(cmd Cmd, r io.Reader, size uint16) (interface{}, error) {
+	c.mu.RLock()
+	decode, ok := c.decoders[cmd]
+	c.mu.RUnlock()
+
+	if !ok {
+		return nil, &ErrUnknownCmd{cmd}
+	}
+
+	return decode(r, size)
+}
+
+// Read decodes one frame from r using the Cmds this Codec knows about.
+func (c *Codec) Read(r io.Reader) (cmd Cmd, msg interface{}, err error) {
+	var size uint16
+	err = binary.Read(r, binary.BigEndian, &size)
+	if err != nil {
+		return
+	}
+
+	b, err := readByte(r)
+	if err != nil {
+		return
+	}
+	cmd = Cmd(b)
+
+	if size == 0 {
+		err = &ErrShortFrame{}
+		return
+	}
+	size--
+
+	msg, err = c.Decode(cmd, r, size)
+	return
+}
+
+// Write encodes msg, a value of a type previously passed to
+// RegisterMessage, as a length-prefixed frame on w.
+func (c *Codec) Write(w io.Writer, msg interface{}) error {
+	typ := reflect.TypeOf(msg)
+
+	c.mu.RLock()
+	cmd, ok := c.encoders[typ]
+	c.mu.RUnlock()
+
+	if !ok {
+		return &ErrUnregisteredMessage{typ}
+	}
+
+	v := reflect.ValueOf(msg)
+	var buf bytes.Buffer
+	var n int64
+	var werr error
+	WriteTo(&buf, cmd, &n, &werr)
+	for f := 0; f < typ.NumField(); f++ {
+		WriteTo(&buf, v.Field(f).Interface().(writable), &n, &werr)
+	}
+	if werr != nil {
+		return werr
+	}
+
+	if err := binary.Write(w, binary.BigEndian, uint16(buf.Len())); err != nil {
+		return err
+	}
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// RegisterMessage registers prototype on DefaultCodec. See
+// (*Codec).RegisterMessage.
+func RegisterMessage(cmd Cmd, prototype interface{}) {
+	DefaultCodec.RegisterMessage(cmd, prototype)
+}
+
+// WriteMsg encodes msg using DefaultCodec. It is the registry-based
+// counterpart to the typed WriteXxx helpers, useful for messages
+// registered by callers rather than built into this package.
+func WriteMsg(w io.Writer, msg interface{}) error {
+	return DefaultCodec.Write(w, msg)
+}
@@ -0,0 +1,70 @@
+package openpoker
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+type msgPing struct {
+	Seq Big
+}
+
+func TestCodecRegisterMessageRoundTrip(t *testing.T) {
+	c := NewCodec()
+	c.RegisterMessage(Cmd(200), msgPing{})
+
+	var buf bytes.Buffer
+	if err := c.Write(&buf, msgPing{Seq: 42}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	cmd, msg, err := c.Read(&buf)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if cmd != Cmd(200) {
+		t.Fatalf("cmd = %v, want 200", cmd)
+	}
+	got, ok := msg.(msgPing)
+	if !ok {
+		t.Fatalf("msg type = %T, want msgPing", msg)
+	}
+	if got.Seq != 42 {
+		t.Fatalf("Seq = %d, want 42", got.Seq)
+	}
+}
+
+// TestCodecReadShortFrame checks that a malformed frame whose declared size
+// is too small to hold even the Cmd byte it just consumed is rejected
+// immediately, rather than underflowing size and reading into the next
+// frame (see ErrShortFrame).
+func TestCodecReadShortFrame(t *testing.T) {
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.BigEndian, uint16(0))
+	buf.WriteByte(byte(GOOD))
+
+	if err := WriteMsg(&buf, MsgBalance{Balance: 1, InPlay: 2}); err != nil {
+		t.Fatalf("WriteMsg: %v", err)
+	}
+	lenAfterGoodFrame := buf.Len() - 3 // the malformed frame is size(2)+cmd(1), no body
+
+	_, _, err := DefaultCodec.Read(&buf)
+	if _, ok := err.(*ErrShortFrame); !ok {
+		t.Fatalf("err = %v (%T), want *ErrShortFrame", err, err)
+	}
+	if buf.Len() != lenAfterGoodFrame {
+		t.Fatalf("Read consumed %d bytes of the next frame", lenAfterGoodFrame-buf.Len())
+	}
+
+	cmd, msg, err := DefaultCodec.Read(&buf)
+	if err != nil {
+		t.Fatalf("Read of following frame: %v", err)
+	}
+	if cmd != BALANCE {
+		t.Fatalf("cmd = %v, want BALANCE", cmd)
+	}
+	if msg.(MsgBalance).Balance != 1 {
+		t.Fatalf("Balance = %v, want 1", msg.(MsgBalance).Balance)
+	}
+}
@@ -0,0 +1,143 @@
+package openpoker
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// BadCode is the error code carried by a MsgBad reply. The wire only sends
+// the byte; the human-readable text in Error.Error() is looked up locally
+// from BadCode.String().
+type BadCode uint8
+
+const (
+	ErrBadLogin            BadCode = 1
+	ErrBadJoin             BadCode = 2
+	ErrSeatTaken           BadCode = 3
+	ErrInsufficientBalance BadCode = 4
+	ErrBadParameter        BadCode = 5
+	ErrAlreadyLoggedIn     BadCode = 6
+	ErrNotLoggedIn         BadCode = 7
+	ErrGameNotFound        BadCode = 8
+	ErrAuthFailed          BadCode = 9
+	ErrProtocolDesync      BadCode = 10
+)
+
+func (c BadCode) String() string {
+	switch c {
+	case ErrBadLogin:
+		return "bad login"
+	case ErrBadJoin:
+		return "bad join"
+	case ErrSeatTaken:
+		return "seat taken"
+	case ErrInsufficientBalance:
+		return "insufficient balance"
+	case ErrBadParameter:
+		return "bad parameter"
+	case ErrAlreadyLoggedIn:
+		return "already logged in"
+	case ErrNotLoggedIn:
+		return "not logged in"
+	case ErrGameNotFound:
+		return "game not found"
+	case ErrAuthFailed:
+		return "authentication failed"
+	case ErrProtocolDesync:
+		return "protocol desync"
+	}
+	return fmt.Sprintf("unknown bad code (%d)", uint8(c))
+}
+
+func (c *BadCode) read(r io.Reader) (int, error) {
+	b, err := readByte(r)
+	if err != nil {
+		return 0, err
+	}
+	*c = BadCode(b)
+	return 1, nil
+}
+
+func (c BadCode) write(w io.Writer) (int, error) {
+	if err := binary.Write(w, binary.BigEndian, c); err != nil {
+		return 0, err
+	}
+	return 1, nil
+}
+
+// IsSoftError reports whether code is recoverable (a rejected request the
+// caller can retry with different parameters) as opposed to fatal (the
+// session itself is broken and should be torn down).
+func IsSoftError(code BadCode) bool {
+	switch code {
+	case ErrBadLogin, ErrBadJoin, ErrSeatTaken, ErrInsufficientBalance, ErrBadParameter, ErrGameNotFound:
+		return true
+	}
+	return false
+}
+
+// Error is the structured form of a MsgBad reply: the Cmd it refers to and
+// the BadCode describing why it was rejected.
+type Error struct {
+	Cmd  Cmd
+	Code BadCode
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Cmd, e.Code)
+}
+
+// ErrUnknownCmd is returned by ReadMsg when the frame's Cmd byte has no
+// registered message type.
+type ErrUnknownCmd struct {
+	Cmd Cmd
+}
+
+func (e *ErrUnknownCmd) Error() string {
+	return fmt.Sprintf("openpoker: cannot deserialize %s: unknown command", e.Cmd)
+}
+
+// ErrShortMessage is returned by ReadMsg when a frame's declared length ran
+// out before all of a message's fields could be populated.
+type ErrShortMessage struct {
+	Cmd       Cmd
+	Remaining uint16
+}
+
+func (e *ErrShortMessage) Error() string {
+	return fmt.Sprintf("openpoker: %s message was too short to populate all fields (%d bytes left after the last complete field)", e.Cmd, e.Remaining)
+}
+
+// ErrLongMessage is returned by ReadMsg when a frame has more bytes left
+// over than a message's fields consumed.
+type ErrLongMessage struct {
+	Cmd    Cmd
+	Excess uint16
+}
+
+func (e *ErrLongMessage) Error() string {
+	return fmt.Sprintf("openpoker: %s message was too long to populate all fields (%d bytes left over)", e.Cmd, e.Excess)
+}
+
+// ErrShortFrame is returned by (*Codec).Read when a frame's declared length
+// is too small to even hold the Cmd byte that was just read. Without this
+// check the subsequent size-- would underflow the uint16 and hand
+// compileDecoder's io.LimitReader a bogus, enormous size, letting it read
+// into the next frame instead of failing.
+type ErrShortFrame struct{}
+
+func (e *ErrShortFrame) Error() string {
+	return "openpoker: frame length too short to hold its Cmd byte"
+}
+
+// ErrUnregisteredMessage is returned by (*Codec).Write when asked to encode
+// a value of a type that was never passed to RegisterMessage.
+type ErrUnregisteredMessage struct {
+	Type reflect.Type
+}
+
+func (e *ErrUnregisteredMessage) Error() string {
+	return fmt.Sprintf("openpoker: %s is not registered with this Codec", e.Type)
+}
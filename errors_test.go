@@ -0,0 +1,41 @@
+package openpoker
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestIsSoftError(t *testing.T) {
+	cases := []struct {
+		code BadCode
+		soft bool
+	}{
+		{ErrBadLogin, true},
+		{ErrSeatTaken, true},
+		{ErrInsufficientBalance, true},
+		{ErrAuthFailed, false},
+		{ErrProtocolDesync, false},
+	}
+	for _, c := range cases {
+		if got := IsSoftError(c.code); got != c.soft {
+			t.Errorf("IsSoftError(%s) = %v, want %v", c.code, got, c.soft)
+		}
+	}
+}
+
+func TestErrorAsMatching(t *testing.T) {
+	var err error = &ErrUnknownCmd{Cmd: Cmd(250)}
+
+	var unknown *ErrUnknownCmd
+	if !errors.As(err, &unknown) {
+		t.Fatalf("errors.As(%v, *ErrUnknownCmd) = false", err)
+	}
+	if unknown.Cmd != Cmd(250) {
+		t.Fatalf("Cmd = %v, want 250", unknown.Cmd)
+	}
+
+	var short *ErrShortMessage
+	if errors.As(err, &short) {
+		t.Fatalf("errors.As(%v, *ErrShortMessage) = true, want false", err)
+	}
+}
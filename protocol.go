@@ -1,57 +1,20 @@
 package openpoker
 
+//go:generate go run ./cmd/genopenpoker
+
 import (
 	"bytes"
 	"encoding/binary"
-	"errors"
-	"fmt"
 	"io"
-	"reflect"
 )
 
+// Cmd constants, the MsgXxx structs, their DefaultCodec registration, the
+// matching WriteXxx helpers and Cmd.String() are generated from
+// spec/spec.go into messages_gen.go by cmd/genopenpoker; run `go generate`
+// after editing the spec. WriteStartGame and WriteGameQuery stay
+// hand-written below because their parameters aren't a straight
+// field-per-field mapping.
 const (
-	GOOD               Cmd = 0
-	LOGIN              Cmd = 1
-	LOGOUT             Cmd = 2
-	BAD                Cmd = 255
-	START_GAME         Cmd = 17
-	YOU_ARE            Cmd = 31
-	YOUR_GAME          Cmd = 39
-	SEAT_QUERY         Cmd = 14
-	SEAT_INFO          Cmd = 30
-	GAME_QUERY         Cmd = 13
-	GAME_INFO          Cmd = 18
-	JOIN               Cmd = 8
-	NOTIFY_JOIN        Cmd = 44
-	WATCH              Cmd = 3
-	NOTIFY_CANCEL_GAME Cmd = 25
-	UNWATCH            Cmd = 4
-	LEAVE              Cmd = 9
-	NOTIFY_LEAVE       Cmd = 45
-	NOTIFY_START_GAME  Cmd = 23
-	NOTIFY_BUTTON      Cmd = 35
-	NOTIFY_SB          Cmd = 36
-	BET_REQ            Cmd = 20
-	RAISE              Cmd = 6
-	BALANCE_QUERY      Cmd = 16
-	BALANCE            Cmd = 33
-	FOLD               Cmd = 7
-	NOTIFY_RAISE       Cmd = 42
-	NOTIFY_BB          Cmd = 37
-	NOTIFY_DRAW        Cmd = 21
-	NOTIFY_SHARED      Cmd = 22
-	NOTIFY_HAND        Cmd = 27
-	NOTIFY_END_GAME    Cmd = 24
-	SIT_OUT            Cmd = 10
-	COME_BACK          Cmd = 11
-	CHAT               Cmd = 12
-	NOTIFY_CHAT        Cmd = 43
-	GAME_STAGE         Cmd = 29
-	SHOW_CARDS         Cmd = 40
-	NOTIFY_WIN         Cmd = 26
-	PLAYER_QUERY       Cmd = 15
-	PLAYER_INFO        Cmd = 19
-
 	GT_TEXAS_HOLDEM GameType = 1
 
 	LIMIT_FIXED LimitType = 1
@@ -105,33 +68,6 @@ const (
 	SPADES   Suit = 4
 )
 
-var msgTypes = map[Cmd]reflect.Type{
-	GOOD:               reflect.TypeOf((*MsgGood)(nil)).Elem(),
-	BAD:                reflect.TypeOf((*MsgBad)(nil)).Elem(),
-	YOU_ARE:            reflect.TypeOf((*MsgYouAre)(nil)).Elem(),
-	YOUR_GAME:          reflect.TypeOf((*MsgYourGame)(nil)).Elem(),
-	SEAT_INFO:          reflect.TypeOf((*MsgSeatInfo)(nil)).Elem(),
-	GAME_INFO:          reflect.TypeOf((*MsgGameInfo)(nil)).Elem(),
-	NOTIFY_JOIN:        reflect.TypeOf((*MsgNotifyJoin)(nil)).Elem(),
-	NOTIFY_CANCEL_GAME: reflect.TypeOf((*MsgNotifyCancelGame)(nil)).Elem(),
-	NOTIFY_LEAVE:       reflect.TypeOf((*MsgNotifyLeave)(nil)).Elem(),
-	NOTIFY_START_GAME:  reflect.TypeOf((*MsgNotifyStartGame)(nil)).Elem(),
-	NOTIFY_BUTTON:      reflect.TypeOf((*MsgNotifyButton)(nil)).Elem(),
-	NOTIFY_SB:          reflect.TypeOf((*MsgNotifySb)(nil)).Elem(),
-	BET_REQ:            reflect.TypeOf((*MsgBetReq)(nil)).Elem(),
-	BALANCE:            reflect.TypeOf((*MsgBalance)(nil)).Elem(),
-	NOTIFY_RAISE:       reflect.TypeOf((*MsgNotifyRaise)(nil)).Elem(),
-	NOTIFY_BB:          reflect.TypeOf((*MsgNotifyBb)(nil)).Elem(),
-	NOTIFY_DRAW:        reflect.TypeOf((*MsgNotifyDraw)(nil)).Elem(),
-	NOTIFY_SHARED:      reflect.TypeOf((*MsgNotifyShared)(nil)).Elem(),
-	NOTIFY_HAND:        reflect.TypeOf((*MsgNotifyHand)(nil)).Elem(),
-	NOTIFY_END_GAME:    reflect.TypeOf((*MsgNotifyEndGame)(nil)).Elem(),
-	NOTIFY_CHAT:        reflect.TypeOf((*MsgNotifyChat)(nil)).Elem(),
-	GAME_STAGE:         reflect.TypeOf((*MsgGameStage)(nil)).Elem(),
-	SHOW_CARDS:         reflect.TypeOf((*MsgShowCards)(nil)).Elem(),
-	NOTIFY_WIN:         reflect.TypeOf((*MsgNotifyWin)(nil)).Elem(),
-}
-
 type Cmd uint8
 type Text string
 type Small uint8
@@ -152,210 +88,337 @@ type Card struct {
 type Cards []Card
 
 type writable interface {
-	write(w io.Writer)
+	write(w io.Writer) (int, error)
 }
 
 type readable interface {
-	read(r io.Reader) uint16
+	read(r io.Reader) (int, error)
 }
 
-func (l *Cards) read(r io.Reader) uint16 {
-	n := readByte(r)
-	*l = make([]Card, n)
-	v := *l
-	for i := uint8(0); i < n; i++ {
-		v[i] = Card{
-			Seq(readByte(r)),
-			Suit(readByte(r)),
-		}
+// WriteTo writes v to w, in the style of Tendermint's binary package: it
+// does nothing if *err is already set, and otherwise writes v and
+// accumulates the bytes it wrote into *n and any error into *err. Chaining
+// calls through the same n/err pair lets a long list of field writes check
+// a single error once at the end instead of after every call.
+func WriteTo(w io.Writer, v writable, n *int64, err *error) {
+	if *err != nil {
+		return
 	}
-
-	return (2 * uint16(n)) + 1
+	written, e := v.write(w)
+	*n += int64(written)
+	*err = e
 }
 
-func (c *Cmd) read(r io.Reader) uint16 {
-	*c = Cmd(readByte(r))
-	return 1
+// ReadFrom reads v from r, in the style of Tendermint's binary package: it
+// does nothing if *err is already set, and otherwise reads v and
+// accumulates the bytes it read into *n and any error into *err.
+func ReadFrom(r io.Reader, v readable, n *int64, err *error) {
+	if *err != nil {
+		return
+	}
+	read, e := v.read(r)
+	*n += int64(read)
+	*err = e
 }
 
-func (c *Text) read(r io.Reader) uint16 {
-	s, l := readString(r)
-	*c = Text(s)
-	return l
+func (l *Cards) read(r io.Reader) (int, error) {
+	n, err := readByte(r)
+	if err != nil {
+		return 0, err
+	}
+	read := 1
+	v := make([]Card, n)
+	for i := uint8(0); i < n; i++ {
+		seq, err := readByte(r)
+		if err != nil {
+			return read, err
+		}
+		read++
+		suit, err := readByte(r)
+		if err != nil {
+			return read, err
+		}
+		read++
+		v[i] = Card{Seq(seq), Suit(suit)}
+	}
+	*l = v
+	return read, nil
 }
 
-func (c *Small) read(r io.Reader) uint16 {
-	*c = Small(readByte(r))
-	return 1
+func (c *Cmd) read(r io.Reader) (int, error) {
+	b, err := readByte(r)
+	if err != nil {
+		return 0, err
+	}
+	*c = Cmd(b)
+	return 1, nil
 }
 
-func (b *Big) read(r io.Reader) uint16 {
-	*b = Big(readInt(r))
-	return 4
+func (c *Text) read(r io.Reader) (int, error) {
+	s, n, err := readString(r)
+	if err != nil {
+		return n, err
+	}
+	*c = Text(s)
+	return n, nil
 }
 
-func (a *Amount) read(r io.Reader) uint16 {
-	*a = Amount(readInt(r)) / 100
-	return 4
+func (c *Small) read(r io.Reader) (int, error) {
+	b, err := readByte(r)
+	if err != nil {
+		return 0, err
+	}
+	*c = Small(b)
+	return 1, nil
 }
 
-func (c *Op) read(r io.Reader) uint16 {
-	*c = Op(readByte(r))
-	return 1
+func (b *Big) read(r io.Reader) (int, error) {
+	v, err := readInt(r)
+	if err != nil {
+		return 0, err
+	}
+	*b = Big(v)
+	return 4, nil
 }
 
-func (c *LimitType) read(r io.Reader) uint16 {
-	*c = LimitType(readByte(r))
-	return 1
+func (a *Amount) read(r io.Reader) (int, error) {
+	v, err := readInt(r)
+	if err != nil {
+		return 0, err
+	}
+	*a = Amount(v) / 100
+	return 4, nil
 }
 
-func (c *GameStage) read(r io.Reader) uint16 {
-	*c = GameStage(readByte(r))
-	return 1
+func (c *Op) read(r io.Reader) (int, error) {
+	b, err := readByte(r)
+	if err != nil {
+		return 0, err
+	}
+	*c = Op(b)
+	return 1, nil
 }
 
-func (c *PlayerState) read(r io.Reader) uint16 {
-	*c = PlayerState(readByte(r))
-	return 1
+func (c *LimitType) read(r io.Reader) (int, error) {
+	b, err := readByte(r)
+	if err != nil {
+		return 0, err
+	}
+	*c = LimitType(b)
+	return 1, nil
 }
 
-func (c *GameType) read(r io.Reader) uint16 {
-	*c = GameType(readByte(r))
-	return 1
+func (c *GameStage) read(r io.Reader) (int, error) {
+	b, err := readByte(r)
+	if err != nil {
+		return 0, err
+	}
+	*c = GameStage(b)
+	return 1, nil
 }
 
-func (c *Seq) read(r io.Reader) uint16 {
-	*c = Seq(readByte(r))
-	return 1
+func (c *PlayerState) read(r io.Reader) (int, error) {
+	b, err := readByte(r)
+	if err != nil {
+		return 0, err
+	}
+	*c = PlayerState(b)
+	return 1, nil
 }
 
-func (c *Suit) read(r io.Reader) uint16 {
-	*c = Suit(readByte(r))
-	return 1
+func (c *GameType) read(r io.Reader) (int, error) {
+	b, err := readByte(r)
+	if err != nil {
+		return 0, err
+	}
+	*c = GameType(b)
+	return 1, nil
 }
 
-func (c *Id) read(r io.Reader) uint16 {
-	*c = Id(readInt(r))
-	return 4
+func (c *Seq) read(r io.Reader) (int, error) {
+	b, err := readByte(r)
+	if err != nil {
+		return 0, err
+	}
+	*c = Seq(b)
+	return 1, nil
 }
 
-func (o LimitType) write(w io.Writer) {
-	binary.Write(w, binary.BigEndian, o)
+func (c *Suit) read(r io.Reader) (int, error) {
+	b, err := readByte(r)
+	if err != nil {
+		return 0, err
+	}
+	*c = Suit(b)
+	return 1, nil
 }
 
-func (i Id) write(w io.Writer) {
-	binary.Write(w, binary.BigEndian, i)
+func (c *Id) read(r io.Reader) (int, error) {
+	v, err := readInt(r)
+	if err != nil {
+		return 0, err
+	}
+	*c = Id(v)
+	return 4, nil
 }
 
-func (g GameType) write(w io.Writer) {
-	binary.Write(w, binary.BigEndian, g)
+func (o LimitType) write(w io.Writer) (int, error) {
+	if err := binary.Write(w, binary.BigEndian, o); err != nil {
+		return 0, err
+	}
+	return 1, nil
 }
 
-func (o Op) write(w io.Writer) {
-	binary.Write(w, binary.BigEndian, o)
+func (i Id) write(w io.Writer) (int, error) {
+	if err := binary.Write(w, binary.BigEndian, i); err != nil {
+		return 0, err
+	}
+	return 4, nil
 }
 
-func (c Cmd) write(w io.Writer) {
-	binary.Write(w, binary.BigEndian, c)
+func (g GameType) write(w io.Writer) (int, error) {
+	if err := binary.Write(w, binary.BigEndian, g); err != nil {
+		return 0, err
+	}
+	return 1, nil
 }
 
-func (n Small) write(w io.Writer) {
-	binary.Write(w, binary.BigEndian, n)
+func (o Op) write(w io.Writer) (int, error) {
+	if err := binary.Write(w, binary.BigEndian, o); err != nil {
+		return 0, err
+	}
+	return 1, nil
 }
 
-func (n Big) write(w io.Writer) {
-	binary.Write(w, binary.BigEndian, n)
+func (c Cmd) write(w io.Writer) (int, error) {
+	if err := binary.Write(w, binary.BigEndian, c); err != nil {
+		return 0, err
+	}
+	return 1, nil
 }
 
-func (n Amount) write(w io.Writer) {
-	Big(n * 100).write(w)
+func (n Small) write(w io.Writer) (int, error) {
+	if err := binary.Write(w, binary.BigEndian, n); err != nil {
+		return 0, err
+	}
+	return 1, nil
 }
 
-func (t Text) write(w io.Writer) {
-	var bytes = []byte(t)
-	binary.Write(w, binary.BigEndian, uint8(len(bytes)))
-	binary.Write(w, binary.BigEndian, bytes)
+func (n Big) write(w io.Writer) (int, error) {
+	if err := binary.Write(w, binary.BigEndian, n); err != nil {
+		return 0, err
+	}
+	return 4, nil
 }
 
-func readByte(r io.Reader) uint8 {
-	var data uint8
-	binary.Read(r, binary.BigEndian, &data)
-	return data
+func (n Amount) write(w io.Writer) (int, error) {
+	return Big(n * 100).write(w)
 }
 
-func readInt(r io.Reader) uint32 {
-	var data uint32
-	binary.Read(r, binary.BigEndian, &data)
-	return data
+func (c PlayerState) write(w io.Writer) (int, error) {
+	if err := binary.Write(w, binary.BigEndian, c); err != nil {
+		return 0, err
+	}
+	return 1, nil
 }
 
-func readString(r io.Reader) (string, uint16) {
-	var size uint8
-	binary.Read(r, binary.BigEndian, &size)
-	var bytes = make([]byte, size)
-	io.ReadFull(r, bytes)
-	return string(bytes), uint16(size + 1)
+func (c GameStage) write(w io.Writer) (int, error) {
+	if err := binary.Write(w, binary.BigEndian, c); err != nil {
+		return 0, err
+	}
+	return 1, nil
 }
 
-func writeMessage(w io.Writer, body ...writable) {
-
-	// fmt.Printf("-> %v\n", body)
-
-	var buf bytes.Buffer
-	for _, v := range body {
-		v.write(&buf)
+func (c Seq) write(w io.Writer) (int, error) {
+	if err := binary.Write(w, binary.BigEndian, c); err != nil {
+		return 0, err
 	}
-	binary.Write(w, binary.BigEndian, uint16(buf.Len()))
-	buf.WriteTo(w)
+	return 1, nil
 }
 
-func ReadMsg(r io.Reader) (err error, c Cmd, msg interface{}) {
-	var size uint16
-	err = binary.Read(r, binary.BigEndian, &size)
-	if err != nil {
-		return
+func (c Suit) write(w io.Writer) (int, error) {
+	if err := binary.Write(w, binary.BigEndian, c); err != nil {
+		return 0, err
 	}
+	return 1, nil
+}
 
-	c = Cmd(readByte(r))
-	size--
-
-	typ := msgTypes[c]
-
-	if typ == nil {
-		err = errors.New(fmt.Sprintf("Cannot deserialize %s", c))
-		return
+func (l Cards) write(w io.Writer) (int, error) {
+	if err := binary.Write(w, binary.BigEndian, uint8(len(l))); err != nil {
+		return 0, err
 	}
-
-	v := reflect.New(typ).Elem()
-	for f := 0; f < typ.NumField(); f++ {
-		i := v.Field(f).Addr().Interface()
-		w := i.(readable)
-		size -= w.read(r)
-
-		if size < 0 {
-			err = errors.New(fmt.Sprintf("The %s message was too short to pOpulate all fields.", c))
-			return
+	n := 1
+	for _, c := range l {
+		if err := binary.Write(w, binary.BigEndian, uint8(c.Seq)); err != nil {
+			return n, err
+		}
+		n++
+		if err := binary.Write(w, binary.BigEndian, uint8(c.Suit)); err != nil {
+			return n, err
 		}
+		n++
 	}
+	return n, nil
+}
 
-	if size > 0 {
-		err = errors.New(fmt.Sprintf("The %s message was too long to pOpulate all fields.", c))
-		return
+func (t Text) write(w io.Writer) (int, error) {
+	b := []byte(t)
+	if err := binary.Write(w, binary.BigEndian, uint8(len(b))); err != nil {
+		return 0, err
+	}
+	if err := binary.Write(w, binary.BigEndian, b); err != nil {
+		return 1, err
 	}
+	return 1 + len(b), nil
+}
 
-	msg = v.Interface()
+func readByte(r io.Reader) (uint8, error) {
+	var data uint8
+	err := binary.Read(r, binary.BigEndian, &data)
+	return data, err
+}
 
-	//fmt.Printf("<- %s %+v\n", c, msg)
+func readInt(r io.Reader) (uint32, error) {
+	var data uint32
+	err := binary.Read(r, binary.BigEndian, &data)
+	return data, err
+}
 
-	return
+func readString(r io.Reader) (string, int, error) {
+	size, err := readByte(r)
+	if err != nil {
+		return "", 0, err
+	}
+	var b = make([]byte, size)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return "", 1, err
+	}
+	return string(b), 1 + int(size), nil
 }
 
-func WriteLogin(w io.Writer, nick Text, pass Text) {
-	writeMessage(w, LOGIN, nick, pass)
+// writeMessage encodes body as a length-prefixed frame on w, short-circuiting
+// on the first field that fails to write.
+func writeMessage(w io.Writer, body ...writable) error {
+	var buf bytes.Buffer
+	var n int64
+	var err error
+	for _, v := range body {
+		WriteTo(&buf, v, &n, &err)
+	}
+	if err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.BigEndian, uint16(buf.Len())); err != nil {
+		return err
+	}
+	_, err = buf.WriteTo(w)
+	return err
 }
 
-func WriteLogout(w io.Writer) {
-	writeMessage(w, LOGOUT)
+// ReadMsg decodes one frame from r using DefaultCodec.
+func ReadMsg(r io.Reader) (err error, c Cmd, msg interface{}) {
+	c, msg, err = DefaultCodec.Read(r)
+	return
 }
 
 func WriteStartGame(
@@ -364,9 +427,9 @@ func WriteStartGame(
 	required Big,
 	l LimitType,
 	low Amount,
-	high Amount) {
+	high Amount) error {
 
-	writeMessage(
+	return writeMessage(
 		w,
 		START_GAME,
 		Text("Test Table"),
@@ -382,14 +445,6 @@ func WriteStartGame(
 	)
 }
 
-func WriteFold(w io.Writer, gid Id) {
-	writeMessage(w, FOLD, gid)
-}
-
-func WriteSeatQuery(w io.Writer, gid Id) {
-	writeMessage(w, SEAT_QUERY, gid)
-}
-
 func WriteGameQuery(
 	w io.Writer,
 	l LimitType,
@@ -398,9 +453,9 @@ func WriteGameQuery(
 	OpJoined Op,
 	joined Small,
 	OpWaiting Op,
-	waiting Small) {
+	waiting Small) error {
 
-	writeMessage(
+	return writeMessage(
 		w,
 		GAME_QUERY,
 		GT_TEXAS_HOLDEM,
@@ -414,194 +469,6 @@ func WriteGameQuery(
 
 }
 
-func WriteJoin(w io.Writer, gid Id, seat Small, amt Amount) {
-	writeMessage(w, JOIN, gid, seat, amt)
-}
-
-func WriteWatch(w io.Writer, gid Id) {
-	writeMessage(w, WATCH, gid)
-}
-
-func WriteUnwatch(w io.Writer, gid Id) {
-	writeMessage(w, UNWATCH, gid)
-}
-
-func WriteLeave(w io.Writer, gid Id) {
-	writeMessage(w, LEAVE, gid)
-}
-
-func WriteRaise(w io.Writer, gid Id, raiseAmount Amount) {
-	writeMessage(w, RAISE, gid, raiseAmount)
-}
-
-func WriteBalanceQuery(w io.Writer) {
-	writeMessage(w, BALANCE_QUERY)
-}
-
-func WriteSitOut(w io.Writer, gid Id) {
-	writeMessage(w, SIT_OUT, gid)
-}
-
-func WriteComeBack(w io.Writer, gid Id) {
-	writeMessage(w, COME_BACK, gid)
-}
-
-func WriteChat(w io.Writer, msg Text) {
-	writeMessage(w, CHAT, msg)
-}
-
-func WritePlayerQuery(w io.Writer, pid Id) {
-	writeMessage(w, PLAYER_QUERY, pid)
-}
-
-type MsgGood struct {
-	Cmd   Cmd
-	Extra Big
-}
-
-type MsgBad struct {
-	Cmd   Cmd
-	Error Small
-}
-
-type MsgYouAre struct {
-	Pid Id
-}
-
-type MsgYourGame struct {
-	Gid Id
-}
-
-type MsgSeatInfo struct {
-	Gid     Id
-	SeatNum Small
-	State   PlayerState
-	Pid     Id
-	InPlay  Amount
-}
-
-type MsgGameInfo struct {
-	Gid       Id
-	TableName Text
-	GameType  GameType
-	LimitType LimitType
-	Low       Amount
-	High      Amount
-	NumSeats  Big
-	Required  Big
-	Joined    Big
-	Waiting   Big
-}
-
-type MsgNotifyJoin struct {
-	Gid    Id
-	Pid    Id
-	Seat   Small
-	Amount Amount
-}
-
-type MsgNotifyCancelGame struct {
-	Gid Id
-}
-
-type MsgNotifyLeave struct {
-	Gid Id
-	Pid Id
-}
-
-type MsgNotifyStartGame struct {
-	Gid Id
-}
-
-type MsgNotifyButton struct {
-	Gid    Id
-	Button Small
-}
-
-type MsgNotifySb struct {
-	Gid Id
-	Sb  Small
-}
-
-type MsgBetReq struct {
-	Gid        Id
-	CallAmount Amount
-	RaiseMin   Amount
-	RaiseMax   Amount
-}
-
-type MsgBalance struct {
-	Balance Amount
-	InPlay  Amount
-}
-
-type MsgNotifyRaise struct {
-	Gid         Id
-	Pid         Id
-	RaiseAmount Amount
-	CallAmount  Amount
-}
-
-type MsgNotifyBb struct {
-	Gid Id
-	Bb  Small
-}
-
-type MsgNotifyDraw struct {
-	Gid  Id
-	Pid  Id
-	Seq  Seq
-	Suit Suit
-}
-
-type MsgNotifyShared struct {
-	Gid  Id
-	Seq  Seq
-	Suit Suit
-}
-
-type MsgNotifyHand struct {
-	Gid   Id
-	Pid   Id
-	Rank  Small
-	Face1 Small
-	Face2 Small
-}
-
-type MsgNotifyEndGame struct {
-	Gid Id
-}
-
-type MsgNotifyChat struct {
-	Gid Id
-	Pid Id
-	Msg Text
-}
-
-type MsgGameStage struct {
-	Gid   Id
-	Stage GameStage
-}
-
-type MsgShowCards struct {
-	Gid   Id
-	Pid   Id
-	Cards Cards
-}
-
-type MsgNotifyWin struct {
-	Gid    Id
-	Pid    Id
-	Amount Amount
-}
-
-type MsgPlayerInfo struct {
-	Pid         Id
-	TotalInPlay Amount
-	Nick        Text
-	Location    Text
-}
-
 func (c GameStage) String() string {
 	switch c {
 	case GS_BLINDS:
@@ -621,91 +488,3 @@ func (c GameStage) String() string {
 	}
 	return "Unknown game stage"
 }
-
-func (c Cmd) String() string {
-	switch c {
-	case GOOD:
-		return "GOOD"
-	case BAD:
-		return "BAD"
-	case LOGIN:
-		return "LOGIN"
-	case START_GAME:
-		return "START_GAME"
-	case YOU_ARE:
-		return "YOU_ARE"
-	case YOUR_GAME:
-		return "YOUR_GAME"
-	case SEAT_INFO:
-		return "SEAT_INFO"
-	case GAME_INFO:
-		return "GAME_INFO"
-	case NOTIFY_JOIN:
-		return "NOTIFY_JOIN"
-	case NOTIFY_CANCEL_GAME:
-		return "NOTIFY_CANCEL_GAME"
-	case LOGOUT:
-		return "LOGOUT"
-	case GAME_QUERY:
-		return "GAME_QUERY"
-	case UNWATCH:
-		return "UNWATCH"
-	case LEAVE:
-		return "LEAVE"
-	case NOTIFY_LEAVE:
-		return "NOTIFY_LEAVE"
-	case WATCH:
-		return "WATCH"
-	case JOIN:
-		return "JOIN"
-	case NOTIFY_START_GAME:
-		return "NOTIFY_START_GAME"
-	case NOTIFY_BUTTON:
-		return "NOTIFY_BUTTON"
-	case NOTIFY_SB:
-		return "NOTIFY_SB"
-	case BET_REQ:
-		return "BET_REQ"
-	case RAISE:
-		return "RAISE"
-	case SEAT_QUERY:
-		return "SEAT_QUERY"
-	case BALANCE_QUERY:
-		return "BALANCE_QUERY"
-	case BALANCE:
-		return "BALANCE"
-	case FOLD:
-		return "FOLD"
-	case NOTIFY_RAISE:
-		return "NOTIFY_RAISE"
-	case NOTIFY_BB:
-		return "NOTIFY_BB"
-	case NOTIFY_DRAW:
-		return "NOTIFY_DRAW"
-	case NOTIFY_SHARED:
-		return "NOTIFY_SHARED"
-	case NOTIFY_HAND:
-		return "NOTIFY_HAND"
-	case NOTIFY_END_GAME:
-		return "NOTIFY_END_GAME"
-	case SIT_OUT:
-		return "SIT_OUT"
-	case COME_BACK:
-		return "COME_BACK"
-	case CHAT:
-		return "CHAT"
-	case NOTIFY_CHAT:
-		return "NOTIFY_CHAT"
-	case GAME_STAGE:
-		return "GAME_STAGE"
-	case SHOW_CARDS:
-		return "SHOW_CARDS"
-	case NOTIFY_WIN:
-		return "NOTIFY_WIN"
-	case PLAYER_QUERY:
-		return "PLAYER_QUERY"
-	case PLAYER_INFO:
-		return "PLAYER_INFO"
-	}
-	return fmt.Sprintf("Unknown Command (%d)", uint8(c))
-}
@@ -0,0 +1,169 @@
+// Package proxy sits between an OpenPoker client and server, decoding each
+// frame just far enough to let middleware observe, drop or modify it before
+// forwarding it on. Middleware returns at most one message per frame it is
+// given, so it cannot inject additional frames. It's useful for recording and replaying bot
+// sessions (see Recorder and Replayer) and for MITM debugging of the wire
+// protocol.
+package proxy
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"reflect"
+
+	openpoker "github.com/felixwatts/openpoker-protocol"
+)
+
+// Direction identifies which way a frame is travelling through the Proxy.
+type Direction int
+
+const (
+	ClientToServer Direction = iota
+	ServerToClient
+)
+
+func (d Direction) String() string {
+	if d == ServerToClient {
+		return "server->client"
+	}
+	return "client->server"
+}
+
+// Middleware observes or rewrites a decoded message before the Proxy
+// forwards it. raw is the frame's original wire bytes (the Cmd byte
+// followed by its encoded body) as read from the source, for middleware
+// that wants to record or compare against what actually crossed the wire
+// without the lossy round trip of re-encoding msg (Amount, for example, is
+// a float32 and doesn't always survive one). Returning a value that
+// reflect.DeepEqual considers equal to msg lets the Proxy forward raw
+// byte-for-byte; returning anything else causes the Proxy to re-encode the
+// frame. Returning a nil msg drops the frame; returning a non-nil error
+// aborts the connection.
+type Middleware func(dir Direction, cmd openpoker.Cmd, msg interface{}, raw []byte) (interface{}, error)
+
+// Proxy accepts client connections and relays them to Upstream, running
+// every Middleware over each decoded frame in both directions.
+type Proxy struct {
+	Upstream    string
+	Middlewares []Middleware
+}
+
+// New returns a Proxy that dials upstream for every accepted connection.
+func New(upstream string, middlewares ...Middleware) *Proxy {
+	return &Proxy{Upstream: upstream, Middlewares: middlewares}
+}
+
+// ListenAndServe listens on addr and proxies every connection to p.Upstream
+// until Accept fails.
+func (p *Proxy) ListenAndServe(addr string) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return p.Serve(l)
+}
+
+// Serve accepts connections from l and proxies each to p.Upstream until
+// Accept fails.
+func (p *Proxy) Serve(l net.Listener) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		go p.handle(conn)
+	}
+}
+
+func (p *Proxy) handle(client net.Conn) {
+	defer client.Close()
+
+	server, err := net.Dial("tcp", p.Upstream)
+	if err != nil {
+		return
+	}
+	defer server.Close()
+
+	done := make(chan struct{}, 2)
+	go func() {
+		forward(client, server, ClientToServer, p.Middlewares)
+		done <- struct{}{}
+	}()
+	go func() {
+		forward(server, client, ServerToClient, p.Middlewares)
+		done <- struct{}{}
+	}()
+	<-done
+}
+
+// forward reads frames from src and writes them to dst, running every
+// middleware over each decoded message first. It returns on the first read
+// or write error (typically the peer closing the connection).
+func forward(src io.Reader, dst io.Writer, dir Direction, middlewares []Middleware) error {
+	for {
+		var size uint16
+		if err := binary.Read(src, binary.BigEndian, &size); err != nil {
+			return err
+		}
+
+		body := make([]byte, size)
+		if _, err := io.ReadFull(src, body); err != nil {
+			return err
+		}
+
+		if len(body) == 0 || len(middlewares) == 0 {
+			if err := writeFrame(dst, size, body); err != nil {
+				return err
+			}
+			continue
+		}
+
+		cmd := openpoker.Cmd(body[0])
+		msg, err := openpoker.DefaultCodec.Decode(cmd, bytes.NewReader(body[1:]), uint16(len(body)-1))
+		if err != nil {
+			// Unknown or unregistered Cmd: forward byte-for-byte, middleware
+			// never sees it.
+			if err := writeFrame(dst, size, body); err != nil {
+				return err
+			}
+			continue
+		}
+
+		out := msg
+		dropped := false
+		for _, mw := range middlewares {
+			out, err = mw(dir, cmd, out, body)
+			if err != nil {
+				return err
+			}
+			if out == nil {
+				dropped = true
+				break
+			}
+		}
+		if dropped {
+			continue
+		}
+
+		if reflect.DeepEqual(out, msg) {
+			if err := writeFrame(dst, size, body); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := openpoker.DefaultCodec.Write(dst, out); err != nil {
+			return err
+		}
+	}
+}
+
+func writeFrame(w io.Writer, size uint16, body []byte) error {
+	if err := binary.Write(w, binary.BigEndian, size); err != nil {
+		return err
+	}
+	_, err := w.Write(body)
+	return err
+}
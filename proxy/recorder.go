@@ -0,0 +1,49 @@
+package proxy
+
+import (
+	"encoding/binary"
+	"io"
+	"sync"
+	"time"
+
+	openpoker "github.com/felixwatts/openpoker-protocol"
+)
+
+// Recorder is a Middleware that appends every frame it sees to a log file
+// as (timestamp, direction, raw wire frame), without altering the frame.
+// The log is read back by a Replayer.
+type Recorder struct {
+	w   io.Writer
+	mu  sync.Mutex
+	now func() time.Time
+}
+
+// NewRecorder returns a Recorder that appends to w.
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{w: w, now: time.Now}
+}
+
+// Middleware is the proxy.Middleware function; pass it to New or append it
+// to Proxy.Middlewares. It records raw verbatim - never re-encoded from
+// msg - so the log always matches what was actually on the wire, even for
+// fields like Amount that don't round-trip exactly through their float32
+// encoding.
+func (rec *Recorder) Middleware(dir Direction, cmd openpoker.Cmd, msg interface{}, raw []byte) (interface{}, error) {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	if err := binary.Write(rec.w, binary.BigEndian, uint64(rec.now().UnixNano())); err != nil {
+		return nil, err
+	}
+	if err := binary.Write(rec.w, binary.BigEndian, uint8(dir)); err != nil {
+		return nil, err
+	}
+	if err := binary.Write(rec.w, binary.BigEndian, uint16(len(raw))); err != nil {
+		return nil, err
+	}
+	if _, err := rec.w.Write(raw); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
@@ -0,0 +1,40 @@
+package proxy
+
+import (
+	"encoding/binary"
+	"io"
+	"time"
+
+	openpoker "github.com/felixwatts/openpoker-protocol"
+)
+
+// Replayer reads a log written by a Recorder back into decoded messages,
+// for deterministic unit testing of bots against a captured session
+// instead of a live server.
+type Replayer struct {
+	r io.Reader
+}
+
+// NewReplayer returns a Replayer reading from r.
+func NewReplayer(r io.Reader) *Replayer {
+	return &Replayer{r: r}
+}
+
+// Next returns the next recorded frame. err is io.EOF once the log is
+// exhausted.
+func (p *Replayer) Next() (ts time.Time, dir Direction, cmd openpoker.Cmd, msg interface{}, err error) {
+	var nanos uint64
+	if err = binary.Read(p.r, binary.BigEndian, &nanos); err != nil {
+		return
+	}
+	ts = time.Unix(0, int64(nanos))
+
+	var d uint8
+	if err = binary.Read(p.r, binary.BigEndian, &d); err != nil {
+		return
+	}
+	dir = Direction(d)
+
+	err, cmd, msg = openpoker.ReadMsg(p.r)
+	return
+}
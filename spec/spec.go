@@ -0,0 +1,164 @@
+// Package spec is the machine-readable description of the OpenPoker wire
+// protocol that cmd/genopenpoker turns into messages_gen.go. Adding a new
+// protocol message is a matter of appending a Message entry here and
+// running `go generate` at the repository root; the Cmd constant, the
+// decoded struct (if any), its DefaultCodec registration, the Cmd.String() case and
+// the typed WriteXxx helper (if any) all follow from this one definition.
+package spec
+
+// Field describes one ordered field of a message body. Type must name one
+// of the scalar or composite types that implement the readable/writable
+// interfaces in protocol.go (Id, Small, Big, Amount, Text, Op, GameType,
+// LimitType, PlayerState, GameStage, Seq, Suit, Cards, BadCode, or a Cmd).
+type Field struct {
+	Name string
+	Type string
+}
+
+// Message is one protocol operation: a Cmd byte plus its ordered body
+// fields. A message is Decodable when the server can send it to the client
+// (cmd/genopenpoker emits a MsgXxx struct, a DefaultCodec registration and a
+// Cmd.String() case for it) and/or Writable when the client can send it to
+// the server (cmd/genopenpoker emits a WriteXxx helper for it). A handful
+// of client->server messages carry parameters that aren't a straight
+// field-per-field mapping (extra hardcoded literals, derived values) and
+// are marked Writable: false here with the helper left hand-written in
+// protocol.go instead; genopenpoker emits only their Cmd constant and
+// Cmd.String() case.
+type Message struct {
+	Name      string // Go identifier suffix: struct is MsgName, helper is WriteName
+	Cmd       string // Cmd constant name
+	Value     uint8
+	Decodable bool
+	Writable  bool
+	Fields    []Field
+}
+
+// Messages is the full protocol table. Order is preserved in generated
+// output so diffs stay small when a single entry changes.
+var Messages = []Message{
+	{Name: "Good", Cmd: "GOOD", Value: 0, Decodable: true, Fields: []Field{
+		{"Cmd", "Cmd"}, {"Extra", "Big"},
+	}},
+	{Name: "Login", Cmd: "LOGIN", Value: 1, Writable: true, Fields: []Field{
+		{"Nick", "Text"}, {"Pass", "Text"},
+	}},
+	{Name: "Logout", Cmd: "LOGOUT", Value: 2, Writable: true},
+	{Name: "Bad", Cmd: "BAD", Value: 255, Decodable: true, Fields: []Field{
+		{"Cmd", "Cmd"}, {"Error", "BadCode"},
+	}},
+	// StartGame carries hardcoded table-name/delay/timeout literals in
+	// WriteStartGame that aren't a field-per-field mapping; left hand-written.
+	{Name: "StartGame", Cmd: "START_GAME", Value: 17},
+	{Name: "YouAre", Cmd: "YOU_ARE", Value: 31, Decodable: true, Fields: []Field{
+		{"Pid", "Id"},
+	}},
+	{Name: "YourGame", Cmd: "YOUR_GAME", Value: 39, Decodable: true, Fields: []Field{
+		{"Gid", "Id"},
+	}},
+	{Name: "SeatQuery", Cmd: "SEAT_QUERY", Value: 14, Writable: true, Fields: []Field{
+		{"Gid", "Id"},
+	}},
+	{Name: "SeatInfo", Cmd: "SEAT_INFO", Value: 30, Decodable: true, Fields: []Field{
+		{"Gid", "Id"}, {"SeatNum", "Small"}, {"State", "PlayerState"}, {"Pid", "Id"}, {"InPlay", "Amount"},
+	}},
+	// GameQuery's Op/value pairs are assembled by the caller; left hand-written.
+	{Name: "GameQuery", Cmd: "GAME_QUERY", Value: 13},
+	{Name: "GameInfo", Cmd: "GAME_INFO", Value: 18, Decodable: true, Fields: []Field{
+		{"Gid", "Id"}, {"TableName", "Text"}, {"GameType", "GameType"}, {"LimitType", "LimitType"},
+		{"Low", "Amount"}, {"High", "Amount"}, {"NumSeats", "Big"}, {"Required", "Big"},
+		{"Joined", "Big"}, {"Waiting", "Big"},
+	}},
+	{Name: "Join", Cmd: "JOIN", Value: 8, Writable: true, Fields: []Field{
+		{"Gid", "Id"}, {"Seat", "Small"}, {"Amt", "Amount"},
+	}},
+	{Name: "NotifyJoin", Cmd: "NOTIFY_JOIN", Value: 44, Decodable: true, Fields: []Field{
+		{"Gid", "Id"}, {"Pid", "Id"}, {"Seat", "Small"}, {"Amount", "Amount"},
+	}},
+	{Name: "Watch", Cmd: "WATCH", Value: 3, Writable: true, Fields: []Field{
+		{"Gid", "Id"},
+	}},
+	{Name: "NotifyCancelGame", Cmd: "NOTIFY_CANCEL_GAME", Value: 25, Decodable: true, Fields: []Field{
+		{"Gid", "Id"},
+	}},
+	{Name: "Unwatch", Cmd: "UNWATCH", Value: 4, Writable: true, Fields: []Field{
+		{"Gid", "Id"},
+	}},
+	{Name: "Leave", Cmd: "LEAVE", Value: 9, Writable: true, Fields: []Field{
+		{"Gid", "Id"},
+	}},
+	{Name: "NotifyLeave", Cmd: "NOTIFY_LEAVE", Value: 45, Decodable: true, Fields: []Field{
+		{"Gid", "Id"}, {"Pid", "Id"},
+	}},
+	{Name: "NotifyStartGame", Cmd: "NOTIFY_START_GAME", Value: 23, Decodable: true, Fields: []Field{
+		{"Gid", "Id"},
+	}},
+	{Name: "NotifyButton", Cmd: "NOTIFY_BUTTON", Value: 35, Decodable: true, Fields: []Field{
+		{"Gid", "Id"}, {"Button", "Small"},
+	}},
+	{Name: "NotifySb", Cmd: "NOTIFY_SB", Value: 36, Decodable: true, Fields: []Field{
+		{"Gid", "Id"}, {"Sb", "Small"},
+	}},
+	{Name: "BetReq", Cmd: "BET_REQ", Value: 20, Decodable: true, Fields: []Field{
+		{"Gid", "Id"}, {"CallAmount", "Amount"}, {"RaiseMin", "Amount"}, {"RaiseMax", "Amount"},
+	}},
+	{Name: "Raise", Cmd: "RAISE", Value: 6, Writable: true, Fields: []Field{
+		{"Gid", "Id"}, {"RaiseAmount", "Amount"},
+	}},
+	{Name: "BalanceQuery", Cmd: "BALANCE_QUERY", Value: 16, Writable: true},
+	{Name: "Balance", Cmd: "BALANCE", Value: 33, Decodable: true, Fields: []Field{
+		{"Balance", "Amount"}, {"InPlay", "Amount"},
+	}},
+	{Name: "Fold", Cmd: "FOLD", Value: 7, Writable: true, Fields: []Field{
+		{"Gid", "Id"},
+	}},
+	{Name: "NotifyRaise", Cmd: "NOTIFY_RAISE", Value: 42, Decodable: true, Fields: []Field{
+		{"Gid", "Id"}, {"Pid", "Id"}, {"RaiseAmount", "Amount"}, {"CallAmount", "Amount"},
+	}},
+	{Name: "NotifyBb", Cmd: "NOTIFY_BB", Value: 37, Decodable: true, Fields: []Field{
+		{"Gid", "Id"}, {"Bb", "Small"},
+	}},
+	{Name: "NotifyDraw", Cmd: "NOTIFY_DRAW", Value: 21, Decodable: true, Fields: []Field{
+		{"Gid", "Id"}, {"Pid", "Id"}, {"Seq", "Seq"}, {"Suit", "Suit"},
+	}},
+	{Name: "NotifyShared", Cmd: "NOTIFY_SHARED", Value: 22, Decodable: true, Fields: []Field{
+		{"Gid", "Id"}, {"Seq", "Seq"}, {"Suit", "Suit"},
+	}},
+	{Name: "NotifyHand", Cmd: "NOTIFY_HAND", Value: 27, Decodable: true, Fields: []Field{
+		{"Gid", "Id"}, {"Pid", "Id"}, {"Rank", "Small"}, {"Face1", "Small"}, {"Face2", "Small"},
+	}},
+	{Name: "NotifyEndGame", Cmd: "NOTIFY_END_GAME", Value: 24, Decodable: true, Fields: []Field{
+		{"Gid", "Id"},
+	}},
+	{Name: "SitOut", Cmd: "SIT_OUT", Value: 10, Writable: true, Fields: []Field{
+		{"Gid", "Id"},
+	}},
+	{Name: "ComeBack", Cmd: "COME_BACK", Value: 11, Writable: true, Fields: []Field{
+		{"Gid", "Id"},
+	}},
+	{Name: "Chat", Cmd: "CHAT", Value: 12, Writable: true, Fields: []Field{
+		{"Msg", "Text"},
+	}},
+	{Name: "NotifyChat", Cmd: "NOTIFY_CHAT", Value: 43, Decodable: true, Fields: []Field{
+		{"Gid", "Id"}, {"Pid", "Id"}, {"Msg", "Text"},
+	}},
+	{Name: "GameStage", Cmd: "GAME_STAGE", Value: 29, Decodable: true, Fields: []Field{
+		{"Gid", "Id"}, {"Stage", "GameStage"},
+	}},
+	{Name: "ShowCards", Cmd: "SHOW_CARDS", Value: 40, Decodable: true, Fields: []Field{
+		{"Gid", "Id"}, {"Pid", "Id"}, {"Cards", "Cards"},
+	}},
+	{Name: "NotifyWin", Cmd: "NOTIFY_WIN", Value: 26, Decodable: true, Fields: []Field{
+		{"Gid", "Id"}, {"Pid", "Id"}, {"Amount", "Amount"},
+	}},
+	{Name: "PlayerQuery", Cmd: "PLAYER_QUERY", Value: 15, Writable: true, Fields: []Field{
+		{"Pid", "Id"},
+	}},
+	// PLAYER_INFO previously had no msgTypes entry (now a DefaultCodec registration) so MsgPlayerInfo was
+	// never decoded; the generator emits the struct and its registration from
+	// this one entry, and its validation pass rejects a Cmd value, Cmd name
+	// or message Name claimed by more than one entry.
+	{Name: "PlayerInfo", Cmd: "PLAYER_INFO", Value: 19, Decodable: true, Fields: []Field{
+		{"Pid", "Id"}, {"TotalInPlay", "Amount"}, {"Nick", "Text"}, {"Location", "Text"},
+	}},
+}